@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/ethermint/types"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
@@ -131,49 +132,135 @@ func (msg MsgEthereumTx) GetSignBytes() []byte {
 	panic("must use 'RLPSignBytes' with a chain ID to get the valid bytes to sign")
 }
 
-// RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
-// given chainID used for signing.
+// RLPSignBytes returns the hash used for signing an Ethereum transaction
+// message with a given chainID. Legacy transactions hash the EIP-155 RLP
+// list; typed (EIP-2930/EIP-1559) transactions hash keccak(type || rlp(fields))
+// instead, per EIP-2718.
 func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
-	return rlpHash([]interface{}{
-		msg.Data.AccountNonce,
-		new(big.Int).SetBytes(msg.Data.Price),
-		msg.Data.GasLimit,
-		ethcmn.BytesToAddress(msg.Data.Recipient),
-		new(big.Int).SetBytes(msg.Data.Amount),
-		msg.Data.Payload,
-		chainID,
-		uint(0),
-		uint(0),
-	})
+	switch msg.Data.TxType {
+	case AccessListTxType:
+		return typedRLPHash(AccessListTxType, []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			new(big.Int).SetBytes(msg.Data.Price),
+			msg.Data.GasLimit,
+			recipientOrNil(msg.Data.Recipient),
+			new(big.Int).SetBytes(msg.Data.Amount),
+			msg.Data.Payload,
+			msg.Data.Accesses.ToEthAccessList(),
+		})
+	case DynamicFeeTxType:
+		return typedRLPHash(DynamicFeeTxType, []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			new(big.Int).SetBytes(msg.Data.GasTipCap),
+			new(big.Int).SetBytes(msg.Data.GasFeeCap),
+			msg.Data.GasLimit,
+			recipientOrNil(msg.Data.Recipient),
+			new(big.Int).SetBytes(msg.Data.Amount),
+			msg.Data.Payload,
+			msg.Data.Accesses.ToEthAccessList(),
+		})
+	default:
+		return rlpHash([]interface{}{
+			msg.Data.AccountNonce,
+			new(big.Int).SetBytes(msg.Data.Price),
+			msg.Data.GasLimit,
+			ethcmn.BytesToAddress(msg.Data.Recipient),
+			new(big.Int).SetBytes(msg.Data.Amount),
+			msg.Data.Payload,
+			chainID,
+			uint(0),
+			uint(0),
+		})
+	}
 }
 
-// EncodeRLP implements the rlp.Encoder interface.
+// EncodeRLP implements the rlp.Encoder interface. Typed transactions are
+// encoded as a byte string carrying the envelope type followed by the RLP
+// encoding of the type's own fields, per EIP-2718; legacy transactions keep
+// the plain RLP list encoding.
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &msg.Data)
+	if msg.Data.TxType == LegacyTxType {
+		return rlp.Encode(w, &msg.Data)
+	}
+
+	payload, err := rlp.EncodeToBytes(msg.typedFields())
+	if err != nil {
+		return err
+	}
+
+	return rlp.Encode(w, append([]byte{msg.Data.TxType}, payload...))
 }
 
-// DecodeRLP implements the rlp.Decoder interface.
+// DecodeRLP implements the rlp.Decoder interface. It peeks at the outer RLP
+// kind: a list is the legacy encoding, while anything else is an EIP-2718
+// typed transaction whose first payload byte (< 0x7f) names the envelope
+// type.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, size, err := s.Kind()
+	kind, size, err := s.Kind()
 	if err != nil {
 		return err
 	}
 
+	if kind != rlp.List {
+		raw, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			return errors.New("typed transaction: empty payload")
+		}
+		if raw[0] >= 0x7f {
+			return fmt.Errorf("unsupported typed transaction type: %#x", raw[0])
+		}
+
+		return msg.decodeTyped(raw[0], raw[1:], size)
+	}
+
 	if err := s.Decode(&msg.Data); err != nil {
 		return err
 	}
 
-	msg.size = float64(ethcmn.StorageSize(rlp.ListSize(size)))
+	msg.Data.TxType = LegacyTxType
+	msg.size.Store(float64(ethcmn.StorageSize(rlp.ListSize(size))))
 	return nil
 }
 
-// Hash hashes the RLP encoding of a transaction.
+// Hash hashes the RLP encoding of a transaction. The digest is cached in an
+// atomic.Value so concurrent readers - mempool inspection, block iteration,
+// eth_getTransactionByHash - can call Hash without racing each other or the
+// first write; the first caller to win the CompareAndSwap computes it for
+// everyone else.
+//
+// NOTE: as with any atomic.Value, callers sharing a tx across goroutines must
+// do so through a pointer to the same MsgEthereumTx (e.g. the instance
+// TxDecoder produced) rather than re-asserting a fresh copy out of an sdk.Tx
+// per goroutine; a struct copy taken concurrently with a Store is undefined.
 func (msg *MsgEthereumTx) Hash() ethcmn.Hash {
-	if len(msg.hash.Bytes()) == 0 {
-		msg.hash = rlpHash(msg)
+	if v := msg.hash.Load(); v != nil {
+		return v.(ethcmn.Hash)
 	}
 
-	return msg.hash
+	var hash ethcmn.Hash
+
+	if msg.Data.TxType == LegacyTxType {
+		hash = rlpHash(msg)
+	} else {
+		// A typed transaction's hash is keccak256(type || rlp(fields)), the
+		// same construction RLPSignBytes/typedRLPHash use for the sighash -
+		// not EncodeRLP's output, which wraps that byte sequence in an outer
+		// RLP string and would hash a different, non-interoperable value.
+		payload, err := rlp.EncodeToBytes(msg.typedFields())
+		if err != nil {
+			panic(err)
+		}
+		hash = ethcmn.BytesToHash(ethcrypto.Keccak256(append([]byte{msg.Data.TxType}, payload...)))
+	}
+
+	msg.hash.CompareAndSwap(nil, hash)
+
+	return msg.hash.Load().(ethcmn.Hash)
 }
 
 // Sign calculates a secp256k1 ECDSA signature and signs the transaction. It
@@ -197,13 +284,20 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
-		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
-	} else {
-		v = big.NewInt(int64(sig[64] + 35))
-		chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
-
-		v.Add(v, chainIDMul)
+	switch msg.Data.TxType {
+	case AccessListTxType, DynamicFeeTxType:
+		// typed transactions carry the bare y_parity (0 or 1) rather than the
+		// EIP-155 35+2*chainID+parity encoding.
+		v = big.NewInt(int64(sig[64]))
+	default:
+		if chainID.Sign() == 0 {
+			v = new(big.Int).SetBytes([]byte{sig[64] + 27})
+		} else {
+			v = big.NewInt(int64(sig[64] + 35))
+			chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
+
+			v.Add(v, chainIDMul)
+		}
 	}
 
 	msg.Data.V = v.Bytes()
@@ -213,42 +307,74 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) {
 
 // VerifySig attempts to verify a Transaction's signature for a given chainID.
 // A derived address is returned upon success or an error if recovery fails.
+// The fast path is a lock-free load of the cached SigCache; ecrecover only
+// runs on a cache miss or a signer mismatch (e.g. a chain ID change), so
+// VerifySig is safe to call concurrently from the JSON-RPC layer, mempool
+// inspection, and block iteration alike.
 func (msg *MsgEthereumTx) VerifySig(chainID *big.Int) (ethcmn.Address, error) {
+	signer, err := msg.signer(chainID)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	if v := msg.from.Load(); v != nil {
+		// If the signer used to derive from in a previous call is not the same as
+		// used current, invalidate the cache.
+		sigCache := v.(*SigCache)
+		if signer.Equal(sigCache.Getsigner()) {
+			return ethcmn.BytesToAddress(sigCache.Getfrom()), nil
+		}
+	}
+
 	r := new(big.Int).SetBytes(msg.Data.R)
 	s := new(big.Int).SetBytes(msg.Data.S)
 	v := new(big.Int).SetBytes(msg.Data.V)
 
-	signer := ethtypes.NewEIP155Signer(chainID)
+	var sender ethcmn.Address
 
-	if msg.from != nil {
-		// If the signer used to derive from in a previous call is not the same as
-		// used current, invalidate the cache.
-		// TODO: signer bytes -> Signer
-		if signer.Equal(msg.from.Getsigner()) {
-			return ethcmn.BytesToAddress(msg.from.Getfrom()), nil
+	switch msg.Data.TxType {
+	case AccessListTxType, DynamicFeeTxType:
+		sigHash := msg.RLPSignBytes(chainID)
+		sender, err = recoverEthSigYParity(r, s, v, sigHash)
+	default:
+		// do not allow recovery for transactions with an unprotected chainID
+		if chainID.Sign() == 0 {
+			return ethcmn.Address{}, errors.New("chainID cannot be zero")
 		}
-	}
 
-	// do not allow recovery for transactions with an unprotected chainID
-	if chainID.Sign() == 0 {
-		return ethcmn.Address{}, errors.New("chainID cannot be zero")
-	}
+		chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
 
-	chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
+		V := new(big.Int).Sub(v, chainIDMul)
+		V.Sub(V, big8)
 
-	V := new(big.Int).Sub(v, chainIDMul)
-	V.Sub(V, big8)
+		sigHash := msg.RLPSignBytes(chainID)
+		sender, err = recoverEthSig(r, s, V, sigHash)
+	}
 
-	sigHash := msg.RLPSignBytes(chainID)
-	sender, err := recoverEthSig(r, s, V, sigHash)
 	if err != nil {
 		return ethcmn.Address{}, err
 	}
 
-	msg.from = &SigCache{signer: signer, from: sender.Bytes()}
+	msg.from.Store(&SigCache{signer: signer, from: sender.Bytes()})
 	return sender, nil
 }
 
+// signer selects the go-ethereum Signer matching the transaction's envelope
+// type: NewLondonSigner for EIP-1559, NewEIP2930Signer for EIP-2930, and
+// NewEIP155Signer for the legacy encoding.
+func (msg MsgEthereumTx) signer(chainID *big.Int) (ethtypes.Signer, error) {
+	switch msg.Data.TxType {
+	case DynamicFeeTxType:
+		return ethtypes.NewLondonSigner(chainID), nil
+	case AccessListTxType:
+		return ethtypes.NewEIP2930Signer(chainID), nil
+	case LegacyTxType:
+		return ethtypes.NewEIP155Signer(chainID), nil
+	default:
+		return nil, fmt.Errorf("unsupported typed transaction type: %#x", msg.Data.TxType)
+	}
+}
+
 // Cost returns amount + gasprice * gaslimit.
 func (msg MsgEthereumTx) Cost() *big.Int {
 	total := msg.Fee()
@@ -256,13 +382,43 @@ func (msg MsgEthereumTx) Cost() *big.Int {
 	return total
 }
 
-// Fee returns gasprice * gaslimit.
+// Fee returns gasprice * gaslimit for legacy and access-list transactions. A
+// dynamic-fee (type-0x02) transaction has no Data.Price to read, so Fee
+// falls back to FeeWithBaseFee(nil) - i.e. GasFeeCap * gaslimit - rather than
+// silently reporting a zero fee; callers that know the block's base fee
+// should call FeeWithBaseFee(baseFee) directly for the exact amount charged.
 func (msg MsgEthereumTx) Fee() *big.Int {
+	if msg.Data.TxType == DynamicFeeTxType {
+		return msg.FeeWithBaseFee(nil)
+	}
+
 	gasPrice := new(big.Int).SetBytes(msg.Data.Price)
 	gasLimit := new(big.Int).SetUint64(msg.Data.GasLimit)
 	return new(big.Int).Mul(gasPrice, gasLimit)
 }
 
+// FeeWithBaseFee returns effectiveGasPrice * gaslimit, where effectiveGasPrice
+// is min(maxFeePerGas, baseFee + maxPriorityFeePerGas) for a type-2
+// transaction; for any other transaction type it is identical to Fee.
+func (msg MsgEthereumTx) FeeWithBaseFee(baseFee *big.Int) *big.Int {
+	if msg.Data.TxType != DynamicFeeTxType {
+		return msg.Fee()
+	}
+
+	gasFeeCap := new(big.Int).SetBytes(msg.Data.GasFeeCap)
+	gasTipCap := new(big.Int).SetBytes(msg.Data.GasTipCap)
+	gasLimit := new(big.Int).SetUint64(msg.Data.GasLimit)
+
+	return new(big.Int).Mul(effectiveGasPrice(baseFee, gasFeeCap, gasTipCap), gasLimit)
+}
+
+// CostWithBaseFee returns amount + FeeWithBaseFee(baseFee).
+func (msg MsgEthereumTx) CostWithBaseFee(baseFee *big.Int) *big.Int {
+	total := msg.FeeWithBaseFee(baseFee)
+	total.Add(total, new(big.Int).SetBytes(msg.Data.Amount))
+	return total
+}
+
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
 	return deriveChainID(new(big.Int).SetBytes(msg.Data.V))
@@ -284,8 +440,9 @@ func deriveChainID(v *big.Int) *big.Int {
 // ----------------------------------------------------------------------------
 // Auxiliary
 
-// TxDecoder returns an sdk.TxDecoder that can decode both auth.StdTx and
-// MsgEthereumTx transactions.
+// TxDecoder returns an sdk.TxDecoder that can decode auth.StdTx,
+// MsgEthereumTx (RLP-style) and MsgEthermint (Cosmos-native) transactions, so
+// a single node accepts either wire format.
 func TxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 	return func(txBytes []byte) (sdk.Tx, error) {
 		var tx sdk.Tx
@@ -301,7 +458,26 @@ func TxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 			return nil, sdkerrors.Wrap(sdkerrors.ErrTxDecode, err.Error())
 		}
 
-		return tx, nil
+		// Reject anything that isn't an auth.StdTx or one of the two EVM
+		// transaction formats here, rather than surfacing a less helpful error further down the
+		// ante handler chain.
+		switch ethTx := tx.(type) {
+		case MsgEthereumTx:
+			// A transaction addressed to the embedded-message recipient whose
+			// payload starts with the magic prefix must decode as a well-formed
+			// envelope; catch a corrupt one at decode
+			// time rather than failing deep inside the EVM handler.
+			if ethTx.hasEmbeddedMsgs() {
+				if _, ok := ethTx.EmbeddedMsgs(cdc); !ok {
+					return nil, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "malformed embedded message envelope")
+				}
+			}
+			return tx, nil
+		case MsgEthermint, authtypes.StdTx:
+			return tx, nil
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrTxDecode, "invalid transaction type: %T", tx)
+		}
 	}
 }
 
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// BenchmarkMsgEthereumTx_HashConcurrent drives concurrent Hash() calls
+// against a single, shared MsgEthereumTx instance. Run with -race to
+// exercise the atomic.Value migration on msg.hash; a data race here means
+// the caching is unsafe for the concurrent eth_getTransactionByHash / block
+// iteration use case it was added for.
+func BenchmarkMsgEthereumTx_HashConcurrent(b *testing.B) {
+	msg := NewMsgEthereumTxContract(0, big.NewInt(100), 21000, big.NewInt(1), nil)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			msg.Hash()
+		}
+	})
+}
+
+// BenchmarkMsgEthereumTx_VerifySigConcurrent drives concurrent VerifySig()
+// calls against a single, shared, already-signed MsgEthereumTx instance. Run
+// with -race: after the first call populates the SigCache, every subsequent
+// call - including from other goroutines - should take the lock-free cache
+// hit path rather than racing on ecrecover.
+func BenchmarkMsgEthereumTx_VerifySigConcurrent(b *testing.B) {
+	priv, err := ethcrypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	chainID := big.NewInt(3)
+	msg := NewMsgEthereumTxContract(0, big.NewInt(100), 21000, big.NewInt(1), nil)
+	msg.Sign(chainID, priv)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := msg.VerifySig(chainID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// embeddedMsgPrefix is the magic 4-byte marker identifying a MsgEthereumTx
+// payload that carries Amino-encoded Cosmos SDK messages rather than EVM
+// calldata.
+var embeddedMsgPrefix = []byte{0xC0, 0x53, 0x05, 0xDC}
+
+// embeddedMsgRecipient is the reserved, precompile-style recipient address
+// that opts a transaction into the embedded-message envelope instead of
+// ordinary contract execution.
+var embeddedMsgRecipient = ethcmn.HexToAddress("0xcc")
+
+// NewMsgEthereumTxEmbedded returns a MsgEthereumTx whose payload carries the
+// given Cosmos SDK messages behind the embedded-message envelope, addressed
+// to the reserved recipient the EVM handler recognizes. It takes cdc, rather
+// than relying on ModuleCdc, because the []sdk.Msg slice must be marshaled
+// with the same codec - carrying every module's registered concrete types -
+// that the caller's app uses, the same reason TxDecoder takes one.
+func NewMsgEthereumTxEmbedded(
+	cdc *codec.Codec, nonce uint64, gasPrice *big.Int, gasLimit uint64, msgs ...sdk.Msg,
+) (MsgEthereumTx, error) {
+	encoded, err := cdc.MarshalBinaryBare(msgs)
+	if err != nil {
+		return MsgEthereumTx{}, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	payload := make([]byte, 0, len(embeddedMsgPrefix)+len(encoded))
+	payload = append(payload, embeddedMsgPrefix...)
+	payload = append(payload, encoded...)
+
+	recipient := embeddedMsgRecipient
+	return newMsgEthereumTx(nonce, &recipient, nil, gasLimit, gasPrice, payload), nil
+}
+
+// EmbeddedMsgs returns the Cosmos SDK messages carried in the transaction's
+// payload, and whether the payload actually used the embedded-message
+// envelope. A malformed envelope (wrong recipient, missing magic, or
+// undecodable Amino) returns (nil, false).
+func (msg MsgEthereumTx) EmbeddedMsgs(cdc *codec.Codec) ([]sdk.Msg, bool) {
+	if !msg.hasEmbeddedMsgs() {
+		return nil, false
+	}
+
+	var msgs []sdk.Msg
+	if err := cdc.UnmarshalBinaryBare(msg.Data.Payload[len(embeddedMsgPrefix):], &msgs); err != nil {
+		return nil, false
+	}
+
+	return msgs, true
+}
+
+// hasEmbeddedMsgs reports whether the transaction is addressed to the
+// reserved embedded-message recipient and its payload carries the magic
+// prefix, without attempting to decode it.
+func (msg MsgEthereumTx) hasEmbeddedMsgs() bool {
+	recipient := msg.To()
+	if recipient == nil || *recipient != embeddedMsgRecipient {
+		return false
+	}
+
+	return bytes.HasPrefix(msg.Data.Payload, embeddedMsgPrefix)
+}
+
+// Router is the minimal surface of baseapp.Router the EVM handler needs to
+// dispatch an embedded message: resolving its route to a registered
+// sdk.Handler. The EVM keeper holds a reference to baseapp's real Router,
+// the same way x/gov and x/evidence hold one for their own routing needs.
+type Router interface {
+	Route(ctx sdk.Context, path string) sdk.Handler
+}
+
+// DispatchEmbeddedMsgs routes the Cosmos SDK messages carried by the
+// embedded-message envelope (see EmbeddedMsgs) through router, exactly as if
+// signer had submitted each of them directly in a StdTx. It is the call the
+// EVM handler makes instead of ordinary contract execution once it observes
+// hasEmbeddedMsgs() is true, using the address VerifySig already recovered as
+// signer so a single Ethereum-format signature authorizes every embedded
+// message.
+//
+// The dispatch runs under its own gas meter capped at msg.Data.GasLimit,
+// exactly as the EVM itself would bound execution, rather than the ambient
+// ctx meter, so an embedded-message transaction can't spend beyond the gas it
+// paid for. Once every message has been dispatched successfully, the gas it
+// consumed is charged against ctx's own meter; an error return charges
+// nothing to ctx.
+func (msg MsgEthereumTx) DispatchEmbeddedMsgs(ctx sdk.Context, cdc *codec.Codec, router Router, signer sdk.AccAddress) ([]byte, error) {
+	msgs, ok := msg.EmbeddedMsgs(cdc)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "payload does not carry an embedded message envelope")
+	}
+
+	gasMeter := sdk.NewGasMeter(msg.Data.GasLimit)
+	dispatchCtx := ctx.WithGasMeter(gasMeter)
+
+	var data []byte
+
+	for _, m := range msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+
+		signers := m.GetSigners()
+		if len(signers) != 1 || !signers[0].Equals(signer) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "embedded message signer does not match the recovered MsgEthereumTx sender")
+		}
+
+		handler := router.Route(dispatchCtx, m.Route())
+		if handler == nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized message route: %s", m.Route())
+		}
+
+		res, err := handler(dispatchCtx, m)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, res.Data...)
+	}
+
+	ctx.GasMeter().ConsumeGas(gasMeter.GasConsumed(), "dispatch embedded messages")
+
+	return data, nil
+}
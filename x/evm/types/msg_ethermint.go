@@ -0,0 +1,129 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ sdk.Msg = MsgEthermint{}
+	_ sdk.Tx  = MsgEthermint{}
+)
+
+// message type and route constants
+const (
+	TypeMsgEthermint = "ethermint"
+)
+
+// MsgEthermint implements a Cosmos-native counterpart to MsgEthereumTx. It
+// carries the same logical fields as an Ethereum transaction, but expresses
+// them with Cosmos SDK types (sdk.Int, sdk.AccAddress) and is signed like any
+// other Cosmos message. This lets Cosmos-only signers, such as the Ledger
+// Cosmos app or gaiacli-style CLI keys, submit EVM transactions without
+// constructing an RLP/EIP-155 payload, while Ethereum tooling keeps using
+// MsgEthereumTx unchanged.
+type MsgEthermint struct {
+	AccountNonce uint64         `json:"account_nonce"`
+	Price        sdk.Int        `json:"price"`
+	GasLimit     uint64         `json:"gas_limit"`
+	Recipient    sdk.AccAddress `json:"recipient,omitempty"`
+	Amount       sdk.Int        `json:"amount"`
+	Payload      []byte         `json:"payload"`
+
+	// From is the Cosmos address of the signer. Unlike MsgEthereumTx, it is
+	// known without needing to recover it from a signature, since
+	// GetSigners/GetSignBytes follow the standard Cosmos signing flow.
+	From sdk.AccAddress `json:"from"`
+}
+
+// NewMsgEthermint returns a reference to a new Ethermint transaction message.
+// A nil 'to' designates a contract creation.
+func NewMsgEthermint(
+	nonce uint64, to *sdk.AccAddress, amount sdk.Int, gasLimit uint64,
+	price sdk.Int, payload []byte, from sdk.AccAddress,
+) MsgEthermint {
+	var recipient sdk.AccAddress
+	if to != nil {
+		recipient = *to
+	}
+
+	if len(payload) > 0 {
+		payload = ethcmn.CopyBytes(payload)
+	}
+
+	return MsgEthermint{
+		AccountNonce: nonce,
+		Price:        price,
+		GasLimit:     gasLimit,
+		Recipient:    recipient,
+		Amount:       amount,
+		Payload:      payload,
+		From:         from,
+	}
+}
+
+// Route returns the route value of a MsgEthermint.
+func (msg MsgEthermint) Route() string { return RouterKey }
+
+// Type returns the type value of a MsgEthermint.
+func (msg MsgEthermint) Type() string { return TypeMsgEthermint }
+
+// ValidateBasic implements the sdk.Msg interface. It applies the same
+// positivity invariants as MsgEthereumTx.ValidateBasic.
+func (msg MsgEthermint) ValidateBasic() error {
+	if msg.Price.Sign() != 1 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "price must be positive %s", msg.Price.String())
+	}
+
+	// Amount can be 0
+	if msg.Amount.IsNegative() {
+		return sdkerrors.Wrap(types.ErrInvalidValue, "amount cannot be negative")
+	}
+
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	return nil
+}
+
+// To returns the recipient address of the transaction. It returns nil if the
+// transaction is a contract creation.
+func (msg MsgEthermint) To() *ethcmn.Address {
+	if msg.Recipient.Empty() {
+		return nil
+	}
+
+	recipient := ethcmn.BytesToAddress(msg.Recipient.Bytes())
+	return &recipient
+}
+
+// GetMsgs returns a single MsgEthermint as an sdk.Msg.
+func (msg MsgEthermint) GetMsgs() []sdk.Msg {
+	return []sdk.Msg{msg}
+}
+
+// GetSigners defines whose signature is required to validate the message. A
+// MsgEthermint always has exactly one, Cosmos-native, signer.
+func (msg MsgEthermint) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// GetSignBytes returns the Amino JSON bytes of a MsgEthermint used for
+// signing, following the standard Cosmos SDK sign-doc convention rather than
+// MsgEthereumTx's RLP sighash.
+func (msg MsgEthermint) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// AsEthereumData converts the Cosmos-native fields of a MsgEthermint into the
+// Ethereum types expected by the EVM state transition, so the handler can
+// route MsgEthermint and MsgEthereumTx through the same execution path.
+func (msg MsgEthermint) AsEthereumData() (to *ethcmn.Address, amount, price *big.Int) {
+	return msg.To(), msg.Amount.BigInt(), msg.Price.BigInt()
+}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// CallArgs represents the arguments for an eth_call or eth_estimateGas
+// JSON-RPC request. Unlike MsgEthereumTx, a CallArgs is never signed: From is
+// supplied directly by the caller rather than recovered from a signature,
+// since both RPC methods permit unsigned calls.
+type CallArgs struct {
+	From     *ethcmn.Address `json:"from"`
+	To       *ethcmn.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+}
+
+// ToMsgEthereumTx converts a CallArgs into the MsgEthereumTx shape consumed
+// by the EVM handler's simulation path, filling in zero values for fields a
+// JSON-RPC caller is allowed to omit and clamping Gas to globalGasCap when
+// the caller left it unset or asked for more than the node allows.
+func (args CallArgs) ToMsgEthereumTx(globalGasCap *big.Int) MsgEthereumTx {
+	gas := uint64(0)
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	if globalGasCap != nil && (gas == 0 || globalGasCap.Uint64() < gas) {
+		gas = globalGasCap.Uint64()
+	}
+
+	gasPrice := big.NewInt(0)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+
+	value := big.NewInt(0)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+
+	return newMsgEthereumTx(0, args.To, value, gas, gasPrice, data)
+}
+
+// AsMessage returns the transaction as an ethtypes.Message suitable for
+// execution against a cached, non-persistent copy of the state (eth_call /
+// eth_estimateGas). If fromOverride is non-nil, signature verification is
+// skipped entirely and fromOverride is used as the sender, matching
+// eth_call's tolerance for unsigned calls; otherwise the sender is recovered
+// the same way a committed transaction would be. The resulting Message is
+// always built with isFake=true: simulation must skip the nonce and
+// signature checks ethtypes.NewMessage otherwise applies, since an
+// eth_call's "from" is never required to hold a valid nonce or signature.
+//
+// Requires go-ethereum >= v1.10.4, the first release carrying both the
+// 11-argument NewMessage (gasFeeCap/gasTipCap/accessList/isFake) and
+// NewLondonSigner used elsewhere in this package.
+func (msg MsgEthereumTx) AsMessage(chainID *big.Int, fromOverride *ethcmn.Address) (ethtypes.Message, error) {
+	var from ethcmn.Address
+
+	if fromOverride != nil {
+		from = *fromOverride
+	} else {
+		sender, err := msg.VerifySig(chainID)
+		if err != nil {
+			return ethtypes.Message{}, err
+		}
+		from = sender
+	}
+
+	gasPrice := new(big.Int).SetBytes(msg.Data.Price)
+	gasFeeCap, gasTipCap := gasPrice, gasPrice
+	if msg.Data.TxType == DynamicFeeTxType {
+		gasFeeCap = new(big.Int).SetBytes(msg.Data.GasFeeCap)
+		gasTipCap = new(big.Int).SetBytes(msg.Data.GasTipCap)
+	}
+
+	return ethtypes.NewMessage(
+		from,
+		msg.To(),
+		msg.Data.AccountNonce,
+		new(big.Int).SetBytes(msg.Data.Amount),
+		msg.Data.GasLimit,
+		gasPrice,
+		gasFeeCap,
+		gasTipCap,
+		msg.Data.Payload,
+		msg.Data.Accesses.ToEthAccessList(),
+		true,
+	), nil
+}
+
+// ExecutionResult is the output of a non-persistent EVM call simulation
+// (eth_call / eth_estimateGas): the raw return data, the gas actually
+// consumed, and - when the call reverted - the VM error. Ret is populated
+// even when VMError is set, so callers can still ABI-decode a revert reason
+// the same way geth does.
+type ExecutionResult struct {
+	Ret     []byte
+	GasUsed uint64
+	VMError string
+}
+
+// EVMExecutor is the surface the EVM keeper exposes for running a read-only
+// call against a cached, non-persistent snapshot of state - typically a
+// CacheContext taken and discarded around the call - without mutating
+// persisted state, incrementing the sender's nonce, or charging fees.
+type EVMExecutor interface {
+	Call(msg ethtypes.Message) (ret []byte, gasUsed uint64, vmErr error)
+}
+
+// Simulate runs msg against evm as an eth_call/eth_estimateGas simulation and
+// reports the raw return data, gas used, and any revert reason, without
+// mutating state. fromOverride, when non-nil, skips signature verification
+// in AsMessage the same way eth_call tolerates an unsigned "from".
+func (msg MsgEthereumTx) Simulate(evm EVMExecutor, chainID *big.Int, fromOverride *ethcmn.Address) (*ExecutionResult, error) {
+	message, err := msg.AsMessage(chainID, fromOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, gasUsed, vmErr := evm.Call(message)
+
+	result := &ExecutionResult{Ret: ret, GasUsed: gasUsed}
+	if vmErr != nil {
+		result.VMError = vmErr.Error()
+	}
+
+	return result, nil
+}